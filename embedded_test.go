@@ -0,0 +1,39 @@
+package xdg
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestConfigFileFallsBackToEmbedded(t *testing.T) {
+	ps := Paths{
+		Override: t.TempDir(),
+		Embedded: fstest.MapFS{
+			"defaults.conf": &fstest.MapFile{Data: []byte("embedded defaults")},
+		},
+	}
+
+	fpath, err := ps.ConfigFile("defaults.conf")
+	if err != nil {
+		t.Fatalf("ConfigFile: %v", err)
+	}
+
+	bs, err := ps.ReadFile(fpath, err)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(bs) != "embedded defaults" {
+		t.Fatalf("ReadFile = %q, want %q", bs, "embedded defaults")
+	}
+}
+
+func TestConfigFileMissingEverywhere(t *testing.T) {
+	ps := Paths{
+		Override: t.TempDir(),
+		Embedded: fstest.MapFS{},
+	}
+
+	if _, err := ps.ConfigFile("nope.conf"); err == nil {
+		t.Fatal("expected an error when no file exists anywhere")
+	}
+}
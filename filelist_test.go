@@ -0,0 +1,63 @@
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigFilesReturnsAllMatchesInPrecedenceOrder(t *testing.T) {
+	home := t.TempDir()
+	sysDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(home, "myapprc"), []byte("user"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sysDir, "myapprc"), []byte("system"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("XDG_CONFIG_DIRS", sysDir)
+	ps := Paths{}
+
+	got, err := ps.ConfigFiles("myapprc")
+	if err != nil {
+		t.Fatalf("ConfigFiles: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(home, "myapprc"),
+		filepath.Join(sysDir, "myapprc"),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ConfigFiles = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ConfigFiles[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestConfigFilesSkipsMissingDirsInPrecedence(t *testing.T) {
+	sysDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(sysDir, "myapprc"), []byte("system"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// XDG_CONFIG_HOME has no matching file, only XDG_CONFIG_DIRS does.
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_DIRS", sysDir)
+	ps := Paths{}
+
+	got, err := ps.ConfigFiles("myapprc")
+	if err != nil {
+		t.Fatalf("ConfigFiles: %v", err)
+	}
+	want := []string{filepath.Join(sysDir, "myapprc")}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("ConfigFiles = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package xdg
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// renameFile is a variable so tests can simulate a rename failing across
+// filesystem boundaries without needing two real filesystems.
+var renameFile = os.Rename
+
+// Migrate looks for name at its normal XDG location for kind. If it's
+// already there, that path is returned unchanged. Otherwise, Migrate walks
+// legacy in order and, for the first path that exists, moves it into the
+// XDG home directory for kind, leaving a symlink at the old location for
+// backward compatibility, and returns the new path. The move is a rename
+// where possible; when the rename fails (for instance across filesystems),
+// the file is copied and the original removed, which is not atomic.
+//
+// If ps.DryRun is true, Migrate neither moves the file nor creates the
+// symlink; it only reports where the move would land.
+//
+// Migrate returns an error if no file is found at any of the normal XDG
+// locations or in legacy.
+func (ps Paths) Migrate(legacy []string, name string, kind Kind) (moved string, err error) {
+	base := ps.basedirs(kind)
+
+	// Deliberately bypasses ps.Embedded: a match there is a synthetic
+	// "embedded:"-prefixed string, not a real filesystem path, and Migrate's
+	// contract is to return a path the caller can open directly.
+	if existing, err := searchPaths(ps.searchDirs(base), name); err == nil {
+		return existing, nil
+	}
+
+	for _, old := range legacy {
+		if !exists(old) {
+			continue
+		}
+
+		dest := filepath.Join(ps.homeDir(base), name)
+		if ps.DryRun {
+			return dest, nil
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dest), base.dirPerm); err != nil {
+			return "", err
+		}
+		if err := moveFile(old, dest); err != nil {
+			return "", err
+		}
+		if err := os.Symlink(dest, old); err != nil {
+			return "", err
+		}
+		return dest, nil
+	}
+
+	return "", fmt.Errorf("xdg: no legacy '%s' file found to migrate", name)
+}
+
+// moveFile renames src to dst, falling back to a copy-then-remove when they
+// live on different filesystems (the case os.Rename can't handle).
+func moveFile(src, dst string) error {
+	if err := renameFile(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(src)
+}
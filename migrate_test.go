@@ -0,0 +1,184 @@
+package xdg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrateAlreadyAtXDGLocation(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{Override: home}
+
+	current := filepath.Join(home, "myapprc")
+	if err := os.WriteFile(current, []byte("current"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := ps.Migrate([]string{filepath.Join(home, "legacy")}, "myapprc", Config)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if moved != current {
+		t.Fatalf("moved = %q, want %q", moved, current)
+	}
+}
+
+func TestMigrateRename(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{Override: home}
+
+	legacy := filepath.Join(home, "legacy", "myapprc")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("legacy contents"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := filepath.Join(home, "myapprc")
+	moved, err := ps.Migrate([]string{legacy}, "myapprc", Config)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if moved != want {
+		t.Fatalf("moved = %q, want %q", moved, want)
+	}
+
+	contents, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatalf("reading moved file: %v", err)
+	}
+	if string(contents) != "legacy contents" {
+		t.Fatalf("moved file contents = %q", contents)
+	}
+
+	fi, err := os.Lstat(legacy)
+	if err != nil {
+		t.Fatalf("lstat legacy path: %v", err)
+	}
+	if fi.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("legacy path %q is not a symlink", legacy)
+	}
+	target, err := os.Readlink(legacy)
+	if err != nil {
+		t.Fatalf("readlink: %v", err)
+	}
+	if target != want {
+		t.Fatalf("symlink target = %q, want %q", target, want)
+	}
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{Override: home, DryRun: true}
+
+	legacy := filepath.Join(home, "legacy", "myapprc")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("legacy contents"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := filepath.Join(home, "myapprc")
+	moved, err := ps.Migrate([]string{legacy}, "myapprc", Config)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if moved != want {
+		t.Fatalf("moved = %q, want %q", moved, want)
+	}
+	if exists(want) {
+		t.Fatalf("dry run should not have created %q", want)
+	}
+	if fi, err := os.Lstat(legacy); err != nil || fi.Mode()&os.ModeSymlink != 0 {
+		t.Fatalf("dry run should not have touched %q", legacy)
+	}
+}
+
+func TestMigrateCrossFilesystemFallback(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{Override: home}
+
+	legacy := filepath.Join(home, "legacy", "myapprc")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("legacy contents"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	orig := renameFile
+	renameFile = func(src, dst string) error {
+		return errors.New("invalid cross-device link")
+	}
+	defer func() { renameFile = orig }()
+
+	want := filepath.Join(home, "myapprc")
+	moved, err := ps.Migrate([]string{legacy}, "myapprc", Config)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if moved != want {
+		t.Fatalf("moved = %q, want %q", moved, want)
+	}
+
+	contents, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatalf("reading moved file: %v", err)
+	}
+	if string(contents) != "legacy contents" {
+		t.Fatalf("moved file contents = %q", contents)
+	}
+
+	fi, err := os.Lstat(legacy)
+	if err != nil {
+		t.Fatalf("lstat legacy path: %v", err)
+	}
+	if fi.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("legacy path %q is not a symlink", legacy)
+	}
+}
+
+func TestMigrateIgnoresEmbeddedForAlreadyPresentCheck(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{
+		Override: home,
+		Embedded: fstest.MapFS{
+			"myapprc": &fstest.MapFile{Data: []byte("embedded default")},
+		},
+	}
+
+	legacy := filepath.Join(home, "legacy", "myapprc")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("legacy contents"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := filepath.Join(home, "myapprc")
+	moved, err := ps.Migrate([]string{legacy}, "myapprc", Config)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if moved != want {
+		t.Fatalf("moved = %q, want %q (an embedded match must not short-circuit the legacy move)", moved, want)
+	}
+	if !exists(want) {
+		t.Fatalf("expected legacy file to have been moved to %q", want)
+	}
+}
+
+func TestMigrateNoneFound(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{Override: home}
+
+	_, err := ps.Migrate([]string{filepath.Join(home, "nope")}, "myapprc", Config)
+	if err == nil {
+		t.Fatal("expected an error when no legacy file is found")
+	}
+}
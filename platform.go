@@ -0,0 +1,46 @@
+//go:build !windows && !darwin
+
+package xdg
+
+import "os"
+
+// This file provides the Unix defaults, i.e. the fallbacks used when the
+// relevant XDG_* environment variable is not set. platform_windows.go and
+// platform_darwin.go provide the equivalents for those platforms.
+
+var configDirs = xdgBasedirs{
+	home: "$XDG_CONFIG_HOME",
+	homeFallback: "$HOME/.config",
+	searchDirs: "$XDG_CONFIG_DIRS",
+	searchDirsFallback: []string{"/etc/xdg"},
+	dirPerm: 0755,
+}
+
+var dataDirs = xdgBasedirs{
+	home: "$XDG_DATA_HOME",
+	homeFallback: "$HOME/.local/share",
+	searchDirs: "$XDG_DATA_DIRS",
+	searchDirsFallback: []string{
+		"/usr/local/share",
+		"/usr/share",
+	},
+	dirPerm: 0755,
+}
+
+var stateDirs = xdgBasedirs{
+	home:         "$XDG_STATE_HOME",
+	homeFallback: "$HOME/.local/state",
+	dirPerm:      0755,
+}
+
+var runtimeDirs = xdgBasedirs{
+	home: "$XDG_RUNTIME_DIR",
+	homeFallback: os.TempDir(),
+	dirPerm: 0700,
+}
+
+var cacheDirs = xdgBasedirs{
+	home: "$XDG_CACHE_HOME",
+	homeFallback: "$HOME/.cache",
+	dirPerm: 0755,
+}
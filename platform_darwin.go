@@ -0,0 +1,35 @@
+package xdg
+
+// This file provides the macOS defaults, i.e. the fallbacks used when the
+// relevant XDG_* environment variable is not set. See platform.go for the
+// Unix defaults and platform_windows.go for the Windows defaults.
+
+var configDirs = xdgBasedirs{
+	home: "$XDG_CONFIG_HOME",
+	homeFallback: "$HOME/Library/Application Support",
+	dirPerm: 0755,
+}
+
+var dataDirs = xdgBasedirs{
+	home: "$XDG_DATA_HOME",
+	homeFallback: "$HOME/Library/Application Support",
+	dirPerm: 0755,
+}
+
+var stateDirs = xdgBasedirs{
+	home:         "$XDG_STATE_HOME",
+	homeFallback: "$HOME/Library/Logs",
+	dirPerm:      0755,
+}
+
+var runtimeDirs = xdgBasedirs{
+	home: "$XDG_RUNTIME_DIR",
+	homeFallback: "$TMPDIR",
+	dirPerm: 0700,
+}
+
+var cacheDirs = xdgBasedirs{
+	home: "$XDG_CACHE_HOME",
+	homeFallback: "$HOME/Library/Caches",
+	dirPerm: 0755,
+}
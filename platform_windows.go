@@ -0,0 +1,35 @@
+package xdg
+
+// This file provides the Windows defaults, i.e. the fallbacks used when the
+// relevant XDG_* environment variable is not set. See platform.go for the
+// Unix defaults and platform_darwin.go for the macOS defaults.
+
+var configDirs = xdgBasedirs{
+	home: "$XDG_CONFIG_HOME",
+	homeFallback: "$APPDATA",
+	dirPerm: 0755,
+}
+
+var dataDirs = xdgBasedirs{
+	home: "$XDG_DATA_HOME",
+	homeFallback: "$APPDATA",
+	dirPerm: 0755,
+}
+
+var stateDirs = xdgBasedirs{
+	home:         "$XDG_STATE_HOME",
+	homeFallback: "$LOCALAPPDATA",
+	dirPerm:      0755,
+}
+
+var runtimeDirs = xdgBasedirs{
+	home: "$XDG_RUNTIME_DIR",
+	homeFallback: "$TEMP",
+	dirPerm: 0700,
+}
+
+var cacheDirs = xdgBasedirs{
+	home: "$XDG_CACHE_HOME",
+	homeFallback: "$LOCALAPPDATA",
+	dirPerm: 0755,
+}
@@ -0,0 +1,47 @@
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigFileSearchesXDGConfigDirs(t *testing.T) {
+	home := t.TempDir()
+	sysDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(sysDir, "myapprc"), []byte("system"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("XDG_CONFIG_DIRS", sysDir)
+	ps := Paths{}
+
+	fpath, err := ps.ConfigFile("myapprc")
+	if err != nil {
+		t.Fatalf("ConfigFile: %v", err)
+	}
+	want := filepath.Join(sysDir, "myapprc")
+	if fpath != want {
+		t.Fatalf("fpath = %q, want %q", fpath, want)
+	}
+}
+
+func TestSearchPathsExpandsXDGConfigDirs(t *testing.T) {
+	sysDir := t.TempDir()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_DIRS", sysDir)
+	ps := Paths{}
+
+	found := false
+	for _, p := range ps.SearchPaths(Config) {
+		if p == sysDir {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("SearchPaths(Config) = %v, want it to contain %q", ps.SearchPaths(Config), sysDir)
+	}
+}
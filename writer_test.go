@@ -0,0 +1,121 @@
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenWriteTruncatesExistingContent(t *testing.T) {
+	base := t.TempDir()
+	ps := Paths{}
+
+	f, err := ps.OpenWrite(base, "myapprc", 0755)
+	if err != nil {
+		t.Fatalf("OpenWrite: %v", err)
+	}
+	if _, err := f.WriteString("a very long initial value"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	f, err = ps.OpenWrite(base, "myapprc", 0755)
+	if err != nil {
+		t.Fatalf("OpenWrite (second): %v", err)
+	}
+	if _, err := f.WriteString("short"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	bs, err := os.ReadFile(filepath.Join(base, "myapprc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bs) != "short" {
+		t.Fatalf("contents = %q, want %q", bs, "short")
+	}
+}
+
+func TestOpenWriteCreatesBaseDir(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "nested", "dir")
+	ps := Paths{}
+
+	f, err := ps.OpenWrite(base, "myapprc", 0755)
+	if err != nil {
+		t.Fatalf("OpenWrite: %v", err)
+	}
+	f.Close()
+
+	if !exists(filepath.Join(base, "myapprc")) {
+		t.Fatalf("expected %q to exist", filepath.Join(base, "myapprc"))
+	}
+}
+
+func TestConfigWriteFile(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{Override: home}
+
+	fpath, err := ps.ConfigWriteFile("myapprc")
+	if err != nil {
+		t.Fatalf("ConfigWriteFile: %v", err)
+	}
+	want := filepath.Join(home, "myapprc")
+	if fpath != want {
+		t.Fatalf("fpath = %q, want %q", fpath, want)
+	}
+	if !exists(want) {
+		t.Fatalf("expected %q to exist", want)
+	}
+}
+
+func TestRuntimeWriteFileUsesRuntimeDirPerm(t *testing.T) {
+	// The runtime directory must not already exist: os.MkdirAll only
+	// applies its perm argument when it actually creates a directory.
+	runtimeDir := filepath.Join(t.TempDir(), "run")
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	ps := Paths{}
+
+	fpath, err := ps.RuntimeWriteFile("myapp.sock")
+	if err != nil {
+		t.Fatalf("RuntimeWriteFile: %v", err)
+	}
+
+	fi, err := os.Stat(filepath.Dir(fpath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0700 {
+		t.Fatalf("base dir perm = %o, want 0700", perm)
+	}
+}
+
+func TestDirMethodsMatchWriteFileDir(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{Override: home}
+
+	if ps.ConfigDir() != home {
+		t.Fatalf("ConfigDir() = %q, want %q", ps.ConfigDir(), home)
+	}
+
+	fpath, err := ps.ConfigWriteFile("myapprc")
+	if err != nil {
+		t.Fatalf("ConfigWriteFile: %v", err)
+	}
+	if filepath.Dir(fpath) != ps.ConfigDir() {
+		t.Fatalf("ConfigWriteFile dir = %q, want %q", filepath.Dir(fpath), ps.ConfigDir())
+	}
+}
+
+func TestSearchPaths(t *testing.T) {
+	home := t.TempDir()
+	ps := Paths{Override: home}
+
+	paths := ps.SearchPaths(Config)
+	if len(paths) == 0 {
+		t.Fatal("expected at least one search path")
+	}
+	if paths[0] != home {
+		t.Fatalf("paths[0] = %q, want %q", paths[0], home)
+	}
+}
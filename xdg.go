@@ -3,9 +3,10 @@ package xdg
 import (
 	"fmt"
 	"go/build"
+	"io/fs"
 	"io/ioutil"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 )
 
@@ -27,6 +28,10 @@ import (
 //	$XDG_DATA_HOME (or $HOME/.local/share when not set)
 //	Directories in $XDG_DATA_DIRS (or /usr/local/share:/usr/share when not set)
 //
+// For state files, these are:
+//
+//	$XDG_STATE_HOME (or $HOME/.local/state when not set)
+//
 // For runtime files, these are:
 //
 //	$XDG_RUNTIME_DIR (or /tmp when not set; implementation defined)
@@ -35,12 +40,24 @@ import (
 //
 //	$XDG_CACHE_HOME (or $HOME/.cache when not set)
 //
-// Finally, the directory specified by GoImportPath is searched in all
+// After that, the directory specified by GoImportPath is searched in all
 // source directories reported by the `go/build` package.
+//
+// Finally, if Embedded is set, it is consulted as a last resort.
+//
+// The fallbacks above are the defaults on Unix-like systems. On Windows and
+// macOS, the fallbacks follow the conventions of those platforms instead
+// (see platform_windows.go and platform_darwin.go). On those platforms the
+// XDG_*_HOME and XDG_RUNTIME_DIR environment variables, when set, still take
+// precedence, but XDG_CONFIG_DIRS and XDG_DATA_DIRS are not consulted.
 type Paths struct {
 	// When non-empty, this will be the first directory searched.
 	Override string
 
+	// When true, Migrate computes and returns the path a legacy file would
+	// be moved to without touching the filesystem.
+	DryRun bool
+
 	// The suffix path appended to XDG directories.
 	// i.e., "wingo" and NOT "/home/andrew/.config/wingo"
 	XDGSuffix string
@@ -53,7 +70,44 @@ type Paths struct {
 	//
 	// N.B. XDGSuffix is not used here,
 	// i.e., "github.com/BurntSushi/wingo/config"
+	//
+	// Deprecated: GoImportPath only works for binaries installed under
+	// GOPATH and does nothing in module mode, which is the common case
+	// today. Use Embedded instead.
 	GoImportPath string
+
+	// Embedded, when non-nil, is consulted as a last resort. The File
+	// methods (ConfigFile, DataFile, ...) use it only after all on-disk XDG
+	// directories (and GoImportPath) have come up empty; the Files methods
+	// (ConfigFiles, DataFiles, ...) list an Embedded match after every
+	// on-disk match. It lets a library ship baked-in default files - via a Go embed.FS - that
+	// still work in module mode, while letting users override them through
+	// the normal XDG hierarchy.
+	//
+	// Paths returned for files found this way are only usable through
+	// ReadFile, MustPanic, and MustError; they are not real filesystem
+	// paths.
+	Embedded fs.FS
+}
+
+// embeddedScheme prefixes the synthetic path returned for a file found in
+// Embedded, so that ReadFile can tell it apart from a real filesystem path.
+// XDG search directories are always absolute, so this can never collide
+// with one.
+const embeddedScheme = "embedded:"
+
+// ReadFile takes the return values of ConfigFile, DataFile, or any of the
+// other File-returning methods, and reads the file into a []byte. If fpath
+// was resolved through Embedded rather than the filesystem, it is
+// dereferenced with fs.ReadFile instead of ioutil.ReadFile.
+func (ps Paths) ReadFile(fpath string, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if name, ok := stripEmbeddedScheme(fpath); ok {
+		return fs.ReadFile(ps.Embedded, name)
+	}
+	return ioutil.ReadFile(fpath)
 }
 
 // MustPanic takes the return values of ConfigFile or DataFile, reads the file
@@ -61,10 +115,7 @@ type Paths struct {
 //
 // If the operation does not succeed, it panics.
 func (ps Paths) MustPanic(fpath string, err error) []byte {
-	if err != nil {
-		panic(err)
-	}
-	bs, err := ioutil.ReadFile(fpath)
+	bs, err := ps.ReadFile(fpath, err)
 	if err != nil {
 		panic(err)
 	}
@@ -74,11 +125,7 @@ func (ps Paths) MustPanic(fpath string, err error) []byte {
 // MustError is like MustPanic, but instead of panicing when something goes
 // wrong, it prints the error to stderr and calls os.Exit(1).
 func (ps Paths) MustError(fpath string, err error) []byte {
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Could not read %s: %s", fpath, err)
-		os.Exit(1)
-	}
-	bs, err := ioutil.ReadFile(fpath)
+	bs, err := ps.ReadFile(fpath, err)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Could not read %s: %s", fpath, err)
 		os.Exit(1)
@@ -86,17 +133,66 @@ func (ps Paths) MustError(fpath string, err error) []byte {
 	return bs
 }
 
+func stripEmbeddedScheme(fpath string) (string, bool) {
+	if strings.HasPrefix(fpath, embeddedScheme) {
+		return strings.TrimPrefix(fpath, embeddedScheme), true
+	}
+	return "", false
+}
+
 type xdgBasedirs struct {
 	home string
 	homeFallback string
 	searchDirs string
 	searchDirsFallback []string
+
+	// The permissions used when creating the home directory via one of the
+	// Write methods. Per the XDG basedir spec, $XDG_RUNTIME_DIR must be
+	// created with mode 0700; everything else uses 0755.
+	dirPerm os.FileMode
 }
 
-func (ps Paths) file(base xdgBasedirs, name string) (string, error) {
-	// We're going to accumulate a list of directories for places to inspect
-	// for files. Basically, this includes following the xdg basedir spec for
-	// the XDG_<>_HOME and XDG_<>_DIRS environment variables.
+// Kind identifies one of the XDG base directory tiers, for use with
+// SearchPaths.
+type Kind int
+
+const (
+	Config Kind = iota
+	Data
+	State
+	Runtime
+	Cache
+)
+
+func (ps Paths) basedirs(kind Kind) xdgBasedirs {
+	switch kind {
+	case Config:
+		return configDirs
+	case Data:
+		return dataDirs
+	case State:
+		return stateDirs
+	case Runtime:
+		return runtimeDirs
+	case Cache:
+		return cacheDirs
+	default:
+		panic(fmt.Sprintf("xdg: unknown Kind %d", kind))
+	}
+}
+
+// SearchPaths returns the ordered list of directories that would be
+// searched for a file of the given kind (after Override and XDGSuffix are
+// applied), without reference to any particular filename. This is useful
+// for tools that want to report or enumerate where they would look.
+func (ps Paths) SearchPaths(kind Kind) []string {
+	return ps.searchDirs(ps.basedirs(kind))
+}
+
+// searchDirs accumulates the list of directories for places to inspect for
+// files of the given kind. Basically, this includes following the xdg
+// basedir spec for the XDG_<>_HOME and XDG_<>_DIRS environment variables.
+func (ps Paths) searchDirs(base xdgBasedirs) []string {
 	var try []string
 
 	// from override
@@ -105,44 +201,119 @@ func (ps Paths) file(base xdgBasedirs, name string) (string, error) {
 	}
 
 	// XDG_<>_HOME
-	if home := os.ExpandEnv(base.home); strings.HasPrefix(home, "/") {
-		try = append(try, path.Join(home, ps.XDGSuffix))
+	if home := os.ExpandEnv(base.home); filepath.IsAbs(home) {
+		try = append(try, filepath.Join(home, ps.XDGSuffix))
 	} else if len(base.homeFallback) > 0 {
 		try = append(
 			try,
-			path.Join(os.ExpandEnv(base.homeFallback), ps.XDGSuffix),
+			filepath.Join(os.ExpandEnv(base.homeFallback), ps.XDGSuffix),
 		)
 	}
 
 	// XDG_<>_DIRS
-	if len(base.searchDirs) > 0 {
-		for _, p := range strings.Split(base.searchDirs, ":") {
+	if dirs := os.ExpandEnv(base.searchDirs); len(dirs) > 0 {
+		for _, p := range strings.Split(dirs, ":") {
 			// XDG basedir spec does not allow relative paths
-			if !strings.HasPrefix(p, "/") {
+			if !filepath.IsAbs(p) {
 				continue
 			}
-			try = append(try, path.Join(p, ps.XDGSuffix))
+			try = append(try, filepath.Join(p, ps.XDGSuffix))
 		}
 	} else {
 		for _, dir := range base.searchDirsFallback {
-			try = append(try, path.Join(dir, ps.XDGSuffix))
+			try = append(try, filepath.Join(dir, ps.XDGSuffix))
 		}
 	}
 
 	// Add directories from GOPATH. Last resort.
 	for _, dir := range build.Default.SrcDirs() {
-		d := path.Join(dir, ps.GoImportPath)
+		d := filepath.Join(dir, ps.GoImportPath)
 		try = append(try, d)
 	}
 
-	return searchPaths(try, name)
+	return try
+}
+
+func (ps Paths) file(base xdgBasedirs, name string) (string, error) {
+	fpath, err := searchPaths(ps.searchDirs(base), name)
+	if err == nil {
+		return fpath, nil
+	}
+	if ps.Embedded != nil {
+		if _, ferr := fs.Stat(ps.Embedded, name); ferr == nil {
+			return embeddedScheme + name, nil
+		}
+	}
+	return "", err
+}
+
+// fileList is like file, but returns every matching path in precedence
+// order instead of stopping at the first one.
+func (ps Paths) fileList(base xdgBasedirs, name string) ([]string, error) {
+	dirs := ps.searchDirs(base)
+	var found, tried []string
+	for _, dir := range dirs {
+		if len(dir) == 0 {
+			continue
+		}
+		fpath := filepath.Join(dir, name)
+		if exists(fpath) {
+			found = append(found, fpath)
+		} else {
+			tried = append(tried, fpath)
+		}
+	}
+	if ps.Embedded != nil {
+		if _, ferr := fs.Stat(ps.Embedded, name); ferr == nil {
+			found = append(found, embeddedScheme+name)
+		}
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("Could not find a '%s' file. Tried "+
+			"the following paths: %s", name, strings.Join(tried, ", "))
+	}
+	return found, nil
+}
+
+// homeDir resolves the single home-tier directory for base, honoring
+// Override and XDGSuffix. Unlike file, it never considers the _DIRS search
+// list: that list exists for reading system-wide defaults, but the spec is
+// clear that only the home directory is ever written to.
+func (ps Paths) homeDir(base xdgBasedirs) string {
+	if len(ps.Override) > 0 {
+		return ps.Override
+	}
+	if home := os.ExpandEnv(base.home); filepath.IsAbs(home) {
+		return filepath.Join(home, ps.XDGSuffix)
+	}
+	return filepath.Join(os.ExpandEnv(base.homeFallback), ps.XDGSuffix)
 }
 
-var configDirs = xdgBasedirs{
-	home: "$XDG_CONFIG_HOME",
-	homeFallback: "$HOME/.config",
-	searchDirs: "$XDG_CONFIG_DIRS",
-	searchDirsFallback: []string{"/etc/xdg"},
+// OpenWrite creates name inside base (creating base itself, with
+// permissions perm, if it does not already exist) and returns it opened
+// for reading and writing, truncating any existing content, Create-style.
+// The caller is responsible for closing it.
+func (ps Paths) OpenWrite(base, name string, perm os.FileMode) (*os.File, error) {
+	if err := os.MkdirAll(base, perm); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(filepath.Join(base, name), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+}
+
+// writeFile resolves name to its home-tier location for base, creates it
+// (and its parent directory) if necessary, and returns its absolute path.
+func (ps Paths) writeFile(base xdgBasedirs, name string) (string, error) {
+	dir := ps.homeDir(base)
+	f, err := ps.OpenWrite(dir, name, base.dirPerm)
+	if err != nil {
+		return "", err
+	}
+	fpath, err := filepath.Abs(f.Name())
+	f.Close()
+	if err != nil {
+		return "", err
+	}
+	return fpath, nil
 }
 
 // ConfigFile returns a file path containing the configuration file
@@ -152,14 +323,25 @@ func (ps Paths) ConfigFile(name string) (string, error) {
 	return ps.file(configDirs, name)
 }
 
-var dataDirs = xdgBasedirs{
-	home: "$XDG_DATA_HOME",
-	homeFallback: "$HOME/.local/share",
-	searchDirs: "$XDG_DATA_DIRS",
-	searchDirsFallback: []string{
-		"/usr/local/share",
-		"/usr/share",
-	},
+// ConfigFiles is like ConfigFile, but returns every matching configuration
+// file in XDG precedence order instead of just the first one. This allows
+// layering system-wide defaults (e.g. in /etc/xdg) with user overrides.
+func (ps Paths) ConfigFiles(name string) ([]string, error) {
+	return ps.fileList(configDirs, name)
+}
+
+// ConfigWriteFile resolves name to a path suitable for writing a
+// configuration file: the home tier only (Override if set, otherwise
+// $XDG_CONFIG_HOME or its fallback), creating the directory if necessary.
+// It returns the absolute path.
+func (ps Paths) ConfigWriteFile(name string) (string, error) {
+	return ps.writeFile(configDirs, name)
+}
+
+// ConfigDir returns the resolved home directory for configuration files,
+// without regard to any particular file therein.
+func (ps Paths) ConfigDir() string {
+	return ps.homeDir(configDirs)
 }
 
 // DataFile returns a file path containing the data file
@@ -169,9 +351,55 @@ func (ps Paths) DataFile(name string) (string, error) {
 	return ps.file(dataDirs, name)
 }
 
-var runtimeDirs = xdgBasedirs{
-	home: "$XDG_RUNTIME_DIR",
-	homeFallback: os.TempDir(),
+// DataFiles is like DataFile, but returns every matching data file in XDG
+// precedence order instead of just the first one.
+func (ps Paths) DataFiles(name string) ([]string, error) {
+	return ps.fileList(dataDirs, name)
+}
+
+// DataWriteFile resolves name to a path suitable for writing a data file:
+// the home tier only (Override if set, otherwise $XDG_DATA_HOME or its
+// fallback), creating the directory if necessary. It returns the absolute
+// path.
+func (ps Paths) DataWriteFile(name string) (string, error) {
+	return ps.writeFile(dataDirs, name)
+}
+
+// DataDir returns the resolved home directory for data files, without
+// regard to any particular file therein.
+func (ps Paths) DataDir() string {
+	return ps.homeDir(dataDirs)
+}
+
+// StateFile returns a file path containing the state file
+// specified. If one cannot be found, an error will be returned which
+// contains a list of all file paths searched.
+//
+// State files are for data that should persist between invocations of an
+// application, but that is neither configuration, cache, nor user-visible
+// data. Logs, history and recently used files are good examples.
+func (ps Paths) StateFile(name string) (string, error) {
+	return ps.file(stateDirs, name)
+}
+
+// StateFiles is like StateFile, but returns every matching state file in
+// XDG precedence order instead of just the first one.
+func (ps Paths) StateFiles(name string) ([]string, error) {
+	return ps.fileList(stateDirs, name)
+}
+
+// StateWriteFile resolves name to a path suitable for writing a state
+// file: the home tier only (Override if set, otherwise $XDG_STATE_HOME or
+// its fallback), creating the directory if necessary. It returns the
+// absolute path.
+func (ps Paths) StateWriteFile(name string) (string, error) {
+	return ps.writeFile(stateDirs, name)
+}
+
+// StateDir returns the resolved home directory for state files, without
+// regard to any particular file therein.
+func (ps Paths) StateDir() string {
+	return ps.homeDir(stateDirs)
 }
 
 // RuntimeFile returns a file path containing the runtime file
@@ -181,9 +409,24 @@ func (ps Paths) RuntimeFile(name string) (string, error) {
 	return ps.file(runtimeDirs, name)
 }
 
-var cacheDirs = xdgBasedirs{
-	home: "$XDG_CACHE_HOME",
-	homeFallback: "$HOME/.cache",
+// RuntimeFiles is like RuntimeFile, but returns every matching runtime
+// file in XDG precedence order instead of just the first one.
+func (ps Paths) RuntimeFiles(name string) ([]string, error) {
+	return ps.fileList(runtimeDirs, name)
+}
+
+// RuntimeWriteFile resolves name to a path suitable for writing a runtime
+// file: the home tier only (Override if set, otherwise $XDG_RUNTIME_DIR or
+// its fallback), creating the directory (with mode 0700, per the spec) if
+// necessary. It returns the absolute path.
+func (ps Paths) RuntimeWriteFile(name string) (string, error) {
+	return ps.writeFile(runtimeDirs, name)
+}
+
+// RuntimeDir returns the resolved home directory for runtime files,
+// without regard to any particular file therein.
+func (ps Paths) RuntimeDir() string {
+	return ps.homeDir(runtimeDirs)
 }
 
 // CacheFile returns a file path containing the cache file
@@ -193,6 +436,26 @@ func (ps Paths) CacheFile(name string) (string, error) {
 	return ps.file(cacheDirs, name)
 }
 
+// CacheFiles is like CacheFile, but returns every matching cache file in
+// XDG precedence order instead of just the first one.
+func (ps Paths) CacheFiles(name string) ([]string, error) {
+	return ps.fileList(cacheDirs, name)
+}
+
+// CacheWriteFile resolves name to a path suitable for writing a cache
+// file: the home tier only (Override if set, otherwise $XDG_CACHE_HOME or
+// its fallback), creating the directory if necessary. It returns the
+// absolute path.
+func (ps Paths) CacheWriteFile(name string) (string, error) {
+	return ps.writeFile(cacheDirs, name)
+}
+
+// CacheDir returns the resolved home directory for cache files, without
+// regard to any particular file therein.
+func (ps Paths) CacheDir() string {
+	return ps.homeDir(cacheDirs)
+}
+
 func searchPaths(paths []string, suffix string) (string, error) {
 	// Now use the first one and keep track of the ones we've tried.
 	tried := make([]string, 0, len(paths))
@@ -201,7 +464,7 @@ func searchPaths(paths []string, suffix string) (string, error) {
 			continue
 		}
 
-		fpath := path.Join(dir, suffix)
+		fpath := filepath.Join(dir, suffix)
 		if exists(fpath) {
 			return fpath, nil
 		}